@@ -15,6 +15,8 @@ import (
 	"go/constant"
 	"go/token"
 	"go/types"
+	"sort"
+	"strings"
 )
 
 // Changes reports on the differences between the APIs of the old and new packages.
@@ -25,15 +27,51 @@ func Changes(old, new *types.Package) Report {
 	d := newDiffer(old, new)
 	d.checkPackage()
 	r := Report{}
-	for _, m := range d.incompatibles.collect() {
-		r.Changes = append(r.Changes, Change{Message: m, Compatible: false})
+	for _, e := range d.incompatibles.collect() {
+		r.Changes = append(r.Changes, d.change(e, false))
 	}
-	for _, m := range d.compatibles.collect() {
-		r.Changes = append(r.Changes, Change{Message: m, Compatible: true})
+	for _, e := range d.compatibles.collect() {
+		r.Changes = append(r.Changes, d.change(e, true))
 	}
+	r.Renames = d.renames()
 	return r
 }
 
+// renames returns the correspondences between exported types of the old and
+// new packages whose names differ. Pairs involving an unexported type are
+// omitted, since clients cannot refer to it by name.
+func (d *differ) renames() []Rename {
+	var rs []Rename
+	for otn, nt := range d.correspondMap {
+		n, ok := nt.(*types.Named)
+		if !ok {
+			continue
+		}
+		ntn := n.Obj()
+		if otn.Exported() && ntn.Exported() && otn.Pkg() == d.old && ntn.Pkg() == d.new && otn.Name() != ntn.Name() {
+			rs = append(rs, Rename{Old: otn, New: ntn})
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Old.Name() < rs[j].Old.Name() })
+	return rs
+}
+
+// change converts a message entry to a Change. If the entry describes a change
+// to a whole declaration, the old and new declarations are included.
+func (d *differ) change(e entry, compatible bool) Change {
+	c := Change{Message: e.text, Compatible: compatible}
+	if e.part == "" && !compatible {
+		c.Kind = d.kinds[e.obj]
+	}
+	if e.part == "" && d.changedDecls[e.obj] {
+		if new := d.counterparts[e.obj]; new != nil {
+			c.Old = e.obj
+			c.New = new
+		}
+	}
+	return c
+}
+
 type differ struct {
 	old, new *types.Package
 	// Correspondences between named types.
@@ -42,6 +80,16 @@ type differ struct {
 	// The values can be either named types or basic types.
 	correspondMap map[*types.TypeName]types.Type
 
+	// Objects in the old package mapped to the objects in the new package
+	// they were compared with.
+	counterparts map[types.Object]types.Object
+	// Old objects whose declarations changed as a whole (their kind or type),
+	// as opposed to a part of them.
+	changedDecls map[types.Object]bool
+	// Kinds of incompatible changes to whole declarations, for those that
+	// have a more specific kind than OtherChange.
+	kinds map[types.Object]ChangeKind
+
 	// Messages.
 	incompatibles messageSet
 	compatibles   messageSet
@@ -52,6 +100,9 @@ func newDiffer(old, new *types.Package) *differ {
 		old:           old,
 		new:           new,
 		correspondMap: map[*types.TypeName]types.Type{},
+		counterparts:  map[types.Object]types.Object{},
+		changedDecls:  map[types.Object]bool{},
+		kinds:         map[types.Object]ChangeKind{},
 		incompatibles: messageSet{},
 		compatibles:   messageSet{},
 	}
@@ -118,6 +169,7 @@ func (d *differ) checkPackage() {
 }
 
 func (d *differ) checkObjects(old, new types.Object) {
+	d.counterparts[old] = new
 	switch old := old.(type) {
 	case *types.Const:
 		if new, ok := new.(*types.Const); ok {
@@ -132,9 +184,14 @@ func (d *differ) checkObjects(old, new types.Object) {
 	case *types.Func:
 		switch new := new.(type) {
 		case *types.Func:
+			if d.addedVariadic(old.Type().(*types.Signature), new.Type().(*types.Signature)) {
+				d.variadicAdded(old, old.Type(), new.Type(), "uses of the function as a value")
+				return
+			}
 			d.checkCorrespondence(old, "", old.Type(), new.Type())
 			return
 		case *types.Var:
+			d.changedDecls[old] = true
 			d.compatible(old, "", "changed from func to var")
 			d.checkCorrespondence(old, "", old.Type(), new.Type())
 			return
@@ -142,13 +199,16 @@ func (d *differ) checkObjects(old, new types.Object) {
 		}
 	case *types.TypeName:
 		if new, ok := new.(*types.TypeName); ok {
-			d.checkCorrespondence(old, "", old.Type(), new.Type())
+			if !d.correspond(old.Type(), new.Type()) {
+				d.typeNameChanged(old, new)
+			}
 			return
 		}
 	default:
 		panic("unexpected obj type")
 	}
 	// Here if kind of type changed.
+	d.changedDecls[old] = true
 	d.incompatible(old, "", "changed from %s to %s",
 		objectKindString(old), objectKindString(new))
 }
@@ -191,11 +251,157 @@ func (d *differ) checkCorrespondence(obj types.Object, part string, old, new typ
 }
 
 func (d *differ) typeChanged(obj types.Object, part string, old, new types.Type) {
+	if part == "" {
+		d.changedDecls[obj] = true
+	}
+	d.incompatible(obj, part, "%s", d.typeChangeString(old, new))
+}
+
+func (d *differ) typeChangeString(old, new types.Type) string {
 	old = removeNamesFromSignature(old)
 	new = removeNamesFromSignature(new)
 	olds := types.TypeString(old, types.RelativeTo(d.old))
 	news := types.TypeString(new, types.RelativeTo(d.new))
-	d.incompatible(obj, part, "changed from %s to %s", olds, news)
+	msg := fmt.Sprintf("changed from %s to %s", olds, news)
+	if oldPath, newPath, ok := d.majorVersionChange(old, new); ok {
+		msg += fmt.Sprintf(" (dependency %s changed to %s)", oldPath, newPath)
+	}
+	return msg
+}
+
+// majorVersionChange looks for a dependency referred to by old that is replaced
+// in new by the same package from a different major version of its module, as
+// when example.com/m/pkg becomes example.com/m/v2/pkg. Types from the two
+// packages are always distinct. The packages being compared are not
+// dependencies, so they are ignored. Only a package that old refers to and
+// new does not can have been replaced, and only by one that new refers to
+// and old does not; otherwise the change lies elsewhere.
+func (d *differ) majorVersionChange(old, new types.Type) (oldPath, newPath string, ok bool) {
+	oldPaths, newPaths := d.dependencyPaths(old), d.dependencyPaths(new)
+	for _, op := range oldPaths {
+		if containsString(newPaths, op) {
+			continue
+		}
+		for _, np := range newPaths {
+			if !containsString(oldPaths, np) && withoutMajorVersion(op) == withoutMajorVersion(np) {
+				return op, np, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func containsString(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencyPaths returns the sorted paths of the packages that t refers to,
+// other than the old and new packages.
+func (d *differ) dependencyPaths(t types.Type) []string {
+	seen := map[string]bool{}
+	types.TypeString(t, func(p *types.Package) string {
+		if p.Path() != d.old.Path() && p.Path() != d.new.Path() {
+			seen[p.Path()] = true
+		}
+		return p.Name()
+	})
+	var paths []string
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// withoutMajorVersion removes a major version element, such as "v2", from an
+// import path.
+func withoutMajorVersion(path string) string {
+	elems := strings.Split(path, "/")
+	for i := 1; i < len(elems); i++ {
+		if isMajorVersion(elems[i]) {
+			return strings.Join(append(elems[:i:i], elems[i+1:]...), "/")
+		}
+	}
+	return path
+}
+
+// isMajorVersion reports whether elem is a major version suffix of a module
+// path, like "v2". Major version 1 has no suffix.
+func isMajorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' || elem[1] == '0' || elem == "v1" {
+		return false
+	}
+	for _, r := range elem[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// typeNameChanged reports an incompatible change to the type named by old.
+// A change between a defined type and an alias, or a change to what an alias
+// refers to, is described in those terms rather than by the types alone,
+// together with the client code that may break.
+func (d *differ) typeNameChanged(old, new *types.TypeName) {
+	if !old.IsAlias() && !new.IsAlias() {
+		d.typeChanged(old, "", old.Type(), new.Type())
+		return
+	}
+	var breaks string
+	if old.IsAlias() {
+		// Clients may have used the alias and the type it referred to as
+		// one and the same, for example in assignments.
+		breaks = fmt.Sprintf("code that uses %s and %s interchangeably",
+			old.Name(), types.TypeString(old.Type(), types.RelativeTo(d.old)))
+	} else {
+		// Clients may have relied on the two types being distinct, for
+		// example in type switches with a case for each.
+		breaks = fmt.Sprintf("code that relies on %s being distinct from %s",
+			old.Name(), types.TypeString(new.Type(), types.RelativeTo(d.new)))
+	}
+	d.changedDecls[old] = true
+	d.incompatible(old, "", "changed from %s to %s, so %s may break",
+		typeNameKind(old, d.old), typeNameKind(new, d.new), breaks)
+}
+
+func typeNameKind(tn *types.TypeName, pkg *types.Package) string {
+	qf := types.RelativeTo(pkg)
+	if tn.IsAlias() {
+		return "alias of " + types.TypeString(tn.Type(), qf)
+	}
+	return "defined type with underlying " + types.TypeString(tn.Type().Underlying(), qf)
+}
+
+// variadicAdded reports that the signature of the function or method obj
+// changed from old to new by the addition of a final variadic parameter, which
+// breaks the given uses but not calls.
+func (d *differ) variadicAdded(obj types.Object, old, new types.Type, breaks string) {
+	d.changedDecls[obj] = true
+	d.kinds[obj] = AddedVariadic
+	d.incompatible(obj, "", "%s, which breaks %s but not calls", d.typeChangeString(old, new), breaks)
+}
+
+// addedVariadic reports whether new is the same as old except for an
+// additional, final variadic parameter. Calls to a function whose signature
+// changes this way still compile, but other uses of the function, like
+// assigning it to a variable of the old type, do not.
+func (d *differ) addedVariadic(old, new *types.Signature) bool {
+	op, np := old.Params(), new.Params()
+	if old.Variadic() || !new.Variadic() || np.Len() != op.Len()+1 {
+		return false
+	}
+	for i := 0; i < op.Len(); i++ {
+		if !d.correspond(op.At(i).Type(), np.At(i).Type()) {
+			return false
+		}
+	}
+	return d.correspond(old.Results(), new.Results())
 }
 
 // go/types always includes the argument and result names when formatting a signature.
@@ -3,6 +3,9 @@ package apidiff
 import (
 	"bufio"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"go/types"
 	"io/ioutil"
 	"os"
@@ -166,3 +169,202 @@ func TestExportedFields(t *testing.T) {
 		}
 	}
 }
+
+func TestChangeDecls(t *testing.T) {
+	oldpkg := checkSource(t, "old", `package p
+func F(int) {}
+func G() {}
+type T int
+var V int
+type S int
+func (S) M(a int) {}
+var W func(a int)
+`, nil)
+	newpkg := checkSource(t, "new", `package p
+func F(int, string) {}
+func G() {}
+type T string
+func V() {}
+type S int
+func (S) M(b string) {}
+var W func(b string)
+`, nil)
+	got := Changes(oldpkg, newpkg).String()
+	want := `Incompatible changes:
+- F: changed from func(int) to func(int, string)
+  -func F(int)
+  +func F(int, string)
+- S.M: changed from func(int) to func(string)
+  -func (S).M(int)
+  +func (S).M(string)
+- T: changed from int to string
+  -type T int
+  +type T string
+- V: changed from var to func
+  -var V int
+  +func V()
+- W: changed from func(int) to func(string)
+  -var W func(int)
+  +var W func(string)
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestChangeKinds(t *testing.T) {
+	oldpkg := checkSource(t, "old", `package p
+func F(int) {}
+func G(int) {}
+type T int
+func (T) M(int) {}
+`, nil)
+	newpkg := checkSource(t, "new", `package p
+func F(int, ...string) {}
+func G(string) {}
+type T int
+func (T) M(int, ...string) {}
+`, nil)
+	got := map[string]ChangeKind{}
+	for _, c := range Changes(oldpkg, newpkg).Changes {
+		got[c.Message[:strings.Index(c.Message, ":")]] = c.Kind
+	}
+	want := map[string]ChangeKind{
+		"F":   AddedVariadic,
+		"G":   OtherChange,
+		"T.M": AddedVariadic,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRenames(t *testing.T) {
+	oldpkg := checkSource(t, "old", `package p
+type u1 int
+type A struct{}
+type B int
+type D int
+var (
+	V1 u1
+	V2 A
+	V3 B
+	V4 D
+)
+`, nil)
+	newpkg := checkSource(t, "new", `package p
+type u2 int
+type C struct{}
+type B int
+type d int
+var (
+	V1 u2
+	V2 C
+	V3 B
+	V4 d
+)
+`, nil)
+	report := Changes(oldpkg, newpkg)
+	var got []string
+	for _, r := range report.Renames {
+		got = append(got, r.Old.Name()+" -> "+r.New.Name())
+	}
+	// Neither u1 -> u2 nor D -> d is listed: clients cannot name u1 or d.
+	want := []string{"A -> C"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if text, want := report.String(), "Renamed types:\n- A: now C\n"; !strings.HasSuffix(text, want) {
+		t.Errorf("got report\n%s\nwant it to end with\n%s", text, want)
+	}
+}
+
+func TestDependencyChanges(t *testing.T) {
+	imp := mapImporter{}
+	for _, path := range []string{"example.com/foo", "example.com/foo/v2", "example.com/bar"} {
+		imp[path] = checkSource(t, path, "package foo; type T int; type U int", nil)
+	}
+	oldpkg := checkSource(t, "old", `package p
+import (
+	"example.com/foo"
+	foo2 "example.com/foo/v2"
+)
+var (
+	V1 foo.T
+	V2 foo.T
+	V3 foo.T
+	V4 foo.T
+)
+func F(foo2.T) {}
+`, imp)
+	newpkg := checkSource(t, "new", `package p
+import (
+	"example.com/foo"
+	foo2 "example.com/foo/v2"
+	bar "example.com/bar"
+)
+type T int
+var (
+	V1 foo.T
+	V2 foo2.T
+	V3 bar.T
+	V4 T
+)
+func F(foo2.T, foo.U) {}
+`, imp)
+	got := Changes(oldpkg, newpkg).messages(false)
+	// F refers to both major versions of foo, but neither replaced the other.
+	want := []string{
+		"F: changed from func(example.com/foo/v2.T) to func(example.com/foo/v2.T, example.com/foo.U)",
+		"V2: changed from example.com/foo.T to example.com/foo/v2.T (dependency example.com/foo changed to example.com/foo/v2)",
+		"V3: changed from example.com/foo.T to example.com/bar.T",
+		"V4: changed from example.com/foo.T to T",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestMajorVersionOfComparedPackage(t *testing.T) {
+	// The packages being compared are not dependencies, so a change involving
+	// their own types is not attributed to a major version change.
+	oldpkg := checkSource(t, "example.com/m/p", `package p
+type T int
+func F(T) {}
+`, nil)
+	newpkg := checkSource(t, "example.com/m/v2/p", `package p
+type T int
+func F(T, int) {}
+`, nil)
+	got := Changes(oldpkg, newpkg).messages(false)
+	want := []string{"F: changed from func(T) to func(T, int)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+}
+
+// A mapImporter resolves imports from a fixed set of packages.
+type mapImporter map[string]*types.Package
+
+func (m mapImporter) Import(path string) (*types.Package, error) {
+	if p := m[path]; p != nil {
+		return p, nil
+	}
+	return nil, fmt.Errorf("package %q not found", path)
+}
+
+// checkSource type-checks a package consisting of the single file src.
+// Imports are resolved with imp, which may be nil if src has no imports.
+func checkSource(t *testing.T, path, src string, imp types.Importer) *types.Package {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path+".go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: imp}
+	pkg, err := conf.Check(path, fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
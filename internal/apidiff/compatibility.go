@@ -300,28 +300,53 @@ func (d *differ) checkMethodSet(otn *types.TypeName, oldt, newt types.Type, addc
 			if receiverNamedType(oldMethod).Obj() != otn {
 				part = fmt.Sprintf(", method set of %s", msname)
 			}
-			d.incompatible(oldMethod, part, "removed")
+			if part == "" && pointerMethod(otn, d.new, newt, name) != nil {
+				// The method is still there, but only for *T, so T no longer
+				// satisfies interfaces that require it.
+				d.incompatible(oldMethod, part, "changed from value to pointer receiver, removing it from the method set of %s", msname)
+			} else {
+				d.incompatible(oldMethod, part, "removed")
+			}
 		} else {
 			obj := oldMethod
 			// If a value method is changed to a pointer method and has a signature
 			// change, then we can get two messages for the same method definition: one
-			// for the value method set that says it's removed, and another for the
-			// pointer method set that says it changed. To keep both messages (since
-			// messageSet dedups), use newMethod for the second. (Slight hack.)
+			// for the value method set that says its receiver changed, and another for
+			// the pointer method set that says its signature changed. To keep both messages
+			// (since messageSet dedups), use newMethod for the second. (Slight hack.)
 			if !hasPointerReceiver(oldMethod) && hasPointerReceiver(newMethod) {
 				obj = newMethod
+			} else {
+				d.counterparts[oldMethod] = newMethod
+			}
+			// A method of a concrete type that gains a final variadic parameter
+			// can still be called as before. For an interface method, it is
+			// a plain incompatible change, because implementations break.
+			osig, nsig := oldMethod.Type().(*types.Signature), newMethod.Type().(*types.Signature)
+			if !types.IsInterface(oldt) && d.addedVariadic(osig, nsig) {
+				d.variadicAdded(obj, osig, nsig, "interface implementations and uses of the method as a value")
+			} else {
+				d.checkCorrespondence(obj, "", osig, nsig)
 			}
-			d.checkCorrespondence(obj, "", oldMethod.Type(), newMethod.Type())
 		}
 	}
 
 	// Check for added methods.
 	for name, newMethod := range newMethodSet {
 		if oldMethodSet[name] == nil {
-			if addcompat {
+			recv := receiverNamedType(newMethod).Obj()
+			if addcompat && recv.Name() == otn.Name() && recv.Pkg() == d.new &&
+				pointerMethod(otn, d.old, oldt, name) != nil {
+				d.compatible(newMethod, "", "changed from pointer to value receiver, adding it to the method set of %s", msname)
+			} else if addcompat {
 				d.compatible(newMethod, "", "added")
 			} else {
-				d.incompatible(newMethod, "", "added")
+				// Types outside the package that implemented the old interface
+				// lack the new method. Implementers inside the package are
+				// reported by checkPackage. The message must not depend on the
+				// interface, because a method of an embedded interface is in
+				// the method set of every interface that embeds it.
+				d.incompatible(newMethod, "", "added, so existing implementations of the interface no longer satisfy it")
 			}
 		}
 	}
@@ -340,6 +365,26 @@ func exportedMethods(t types.Type) map[string]types.Object {
 	return m
 }
 
+// pointerMethod returns the exported method called name in the method set of *t,
+// if t is not a pointer and that method is declared with a pointer receiver on
+// the type in pkg with the same name as otn. Otherwise it returns nil. A method
+// found this way is not in the method set of t itself. Methods promoted from
+// embedded fields are not considered, because the receiver of the outer type's
+// own method did not change.
+func pointerMethod(otn *types.TypeName, pkg *types.Package, t types.Type, name string) types.Object {
+	if _, ok := t.(*types.Pointer); ok {
+		return nil
+	}
+	m := exportedMethods(types.NewPointer(t))[name]
+	if m == nil || !hasPointerReceiver(m) {
+		return nil
+	}
+	if recv := receiverNamedType(m).Obj(); recv.Name() != otn.Name() || recv.Pkg() != pkg {
+		return nil
+	}
+	return m
+}
+
 func receiverType(method types.Object) types.Type {
 	return method.Type().(*types.Signature).Recv().Type()
 }
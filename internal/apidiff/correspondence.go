@@ -174,12 +174,25 @@ func (d *differ) establishCorrespondence(old *types.Named, new types.Type) bool
 		// That is a bit hard, because there is no easy way to find a new package
 		// matching an old one.
 		if newn, ok := new.(*types.Named); ok {
-			if old.Obj().Pkg() != d.old || newn.Obj().Pkg() != d.new {
+			oldp, newp := old.Obj().Pkg(), newn.Obj().Pkg()
+			if oldp != d.old || newp != d.new {
+				// A type from another package can only correspond to the same
+				// type, so the package paths must match as well as the names.
+				// Otherwise a change like foo.T to bar.T, to a different major
+				// version of foo, or to a local T would go unnoticed.
+				// An old local type may correspond to a type of the same name in
+				// another package, as when it is replaced by an alias to it.
+				if oldp != d.old && pkgPath(oldp) != pkgPath(newp) {
+					return false
+				}
 				return old.Obj().Id() == newn.Obj().Id()
 			}
 		}
 		// If there is no correspondence, create one.
 		d.correspondMap[oldname] = new
+		if newn, ok := new.(*types.Named); ok && d.counterparts[oldname] == nil {
+			d.counterparts[oldname] = newn.Obj()
+		}
 		// Check that the corresponding types are compatible.
 		d.checkCompatibleDefined(oldname, old, new)
 		return true
@@ -187,6 +200,14 @@ func (d *differ) establishCorrespondence(old *types.Named, new types.Type) bool
 	return types.Identical(oldc, new)
 }
 
+// pkgPath returns the path of p, or "" for the universe scope.
+func pkgPath(p *types.Package) string {
+	if p == nil {
+		return ""
+	}
+	return p.Path()
+}
+
 func (d *differ) sortedMethods(iface *types.Interface) []*types.Func {
 	ms := make([]*types.Func, iface.NumMethods())
 	for i := 0; i < iface.NumMethods(); i++ {
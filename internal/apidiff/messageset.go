@@ -34,8 +34,16 @@ func (m messageSet) add(obj types.Object, part, msg string) {
 	s[part] = msg
 }
 
-func (m messageSet) collect() []string {
-	var s []string
+// An entry is a single formatted message, along with the object and part it
+// describes.
+type entry struct {
+	obj  types.Object
+	part string
+	text string
+}
+
+func (m messageSet) collect() []entry {
+	var s []entry
 	for obj, parts := range m {
 		// Format each object name relative to its own package.
 		objstring := objectString(obj)
@@ -47,10 +55,10 @@ func (m messageSet) collect() []string {
 			} else {
 				p = dotjoin(objstring, part)
 			}
-			s = append(s, p+": "+msg)
+			s = append(s, entry{obj: obj, part: part, text: p + ": " + msg})
 		}
 	}
-	sort.Strings(s)
+	sort.Slice(s, func(i, j int) bool { return s[i].text < s[j].text })
 	return s
 }
 
@@ -3,27 +3,73 @@ package apidiff
 import (
 	"bytes"
 	"fmt"
+	"go/types"
 	"io"
 )
 
 // Report describes the changes detected by Changes.
 type Report struct {
 	Changes []Change
+
+	// Renames lists the exported types of the old package that correspond
+	// to differently named exported types of the new package, sorted by
+	// old name.
+	Renames []Rename
+}
+
+// A Rename records that a type in the old package corresponds to a type with a
+// different name in the new package. Corresponding types can be used in place
+// of each other, so uses of the old name can be migrated to the new one.
+type Rename struct {
+	Old, New *types.TypeName
 }
 
 // A Change describes a single API change.
 type Change struct {
 	Message    string
 	Compatible bool
+
+	// Kind classifies the change more precisely than Compatible, for
+	// changes that call for special treatment. Most changes have kind
+	// OtherChange.
+	Kind ChangeKind
+
+	// Old and New are the declarations in the old and new packages, set when
+	// the change is to a declaration as a whole, such as a change in the
+	// signature of a function. Otherwise they are nil.
+	Old, New types.Object
 }
 
-func (r Report) messages(compatible bool) []string {
-	var msgs []string
+// A ChangeKind classifies a change.
+type ChangeKind int
+
+const (
+	// OtherChange is a change that has no more specific kind.
+	OtherChange ChangeKind = iota
+
+	// AddedVariadic is the addition of a final variadic parameter to a
+	// function or to a method of a concrete type. The change is incompatible,
+	// but calls written against the old signature still compile; only other
+	// uses of the function, such as assigning it to a variable of the old
+	// type, break.
+	AddedVariadic
+)
+
+func (r Report) changes(compatible bool) []Change {
+	var cs []Change
 	for _, c := range r.Changes {
 		if c.Compatible == compatible {
-			msgs = append(msgs, c.Message)
+			cs = append(cs, c)
 		}
 	}
+	return cs
+}
+
+func (r Report) messages(compatible bool) []string {
+	var msgs []string
+	for _, c := range r.changes(compatible) {
+		msgs = append(msgs, c.Message)
+	}
 	return msgs
 }
 
@@ -39,22 +85,40 @@ func (r Report) Text(w io.Writer) error {
 	if err := r.TextIncompatible(w, true); err != nil {
 		return err
 	}
-	return r.TextCompatible(w)
+	if err := r.TextCompatible(w); err != nil {
+		return err
+	}
+	return r.textRenames(w)
+}
+
+func (r Report) textRenames(w io.Writer) error {
+	if len(r.Renames) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "Renamed types:\n"); err != nil {
+		return err
+	}
+	for _, rn := range r.Renames {
+		if _, err := fmt.Fprintf(w, "- %s: now %s\n", rn.Old.Name(), rn.New.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r Report) TextIncompatible(w io.Writer, withHeader bool) error {
 	if withHeader {
-		return r.writeMessages(w, "Incompatible changes:", r.messages(false))
+		return r.writeChanges(w, "Incompatible changes:", r.changes(false))
 	}
-	return r.writeMessages(w, "", r.messages(false))
+	return r.writeChanges(w, "", r.changes(false))
 }
 
 func (r Report) TextCompatible(w io.Writer) error {
-	return r.writeMessages(w, "Compatible changes:", r.messages(true))
+	return r.writeChanges(w, "Compatible changes:", r.changes(true))
 }
 
-func (r Report) writeMessages(w io.Writer, header string, msgs []string) error {
-	if len(msgs) == 0 {
+func (r Report) writeChanges(w io.Writer, header string, cs []Change) error {
+	if len(cs) == 0 {
 		return nil
 	}
 	if header != "" {
@@ -62,10 +126,31 @@ func (r Report) writeMessages(w io.Writer, header string, msgs []string) error {
 			return err
 		}
 	}
-	for _, m := range msgs {
-		if _, err := fmt.Fprintf(w, "- %s\n", m); err != nil {
+	for _, c := range cs {
+		if _, err := fmt.Fprintf(w, "- %s\n", c.Message); err != nil {
+			return err
+		}
+		if c.Old == nil || c.New == nil {
+			continue
+		}
+		// Show the old and new declarations in the style of a diff.
+		if _, err := fmt.Fprintf(w, "  -%s\n  +%s\n", declString(c.Old), declString(c.New)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// declString formats the declaration of obj relative to its own package.
+// As in change messages, parameter and result names are omitted, so that
+// renaming them does not show up as a difference.
+func declString(obj types.Object) string {
+	switch o := obj.(type) {
+	case *types.Func:
+		sig := removeNamesFromSignature(o.Type()).(*types.Signature)
+		obj = types.NewFunc(o.Pos(), o.Pkg(), o.Name(), sig)
+	case *types.Var:
+		obj = types.NewVar(o.Pos(), o.Pkg(), o.Name(), removeNamesFromSignature(o.Type()))
+	}
+	return types.ObjectString(obj, types.RelativeTo(obj.Pkg()))
+}
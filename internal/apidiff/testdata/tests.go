@@ -10,7 +10,12 @@
 package ignore
 
 // both
-import "io"
+import (
+	"io"
+	"time"
+)
+
+var _ time.Month
 
 //////////////// Basics
 
@@ -79,7 +84,7 @@ type (
 	Split1 = u2 // OK, since old u1 corresponds to new u2
 
 	// This tries to make u1 correspond to u3
-	// i Split2: changed from u1 to u3
+	// i Split2: changed from alias of u1 to alias of u3, so code that uses Split2 and u1 interchangeably may break
 	Split2 = u3
 )
 
@@ -362,11 +367,33 @@ type I1 interface {
 	M2(int)
 	// i I1.M2: changed from func() to func(int)
 	M3()
-	// i I1.M3: added
+	// i I1.M3: added, so existing implementations of the interface no longer satisfy it
 	m()
 	// i I1.m: added unexported method
 }
 
+// old
+type IfJ interface {
+	A()
+}
+
+type IfI interface {
+	IfJ
+}
+
+// new
+// The added method is in the method sets of both IfJ and IfI,
+// but is reported once.
+type IfJ interface {
+	A()
+	// i IfJ.B: added, so existing implementations of the interface no longer satisfy it
+	B()
+}
+
+type IfI interface {
+	IfJ
+}
+
 // old
 type I2 interface {
 	M1()
@@ -410,7 +437,7 @@ type I4 interface {
 type I5 = io.Writer
 
 // new
-// i I5: changed from io.Writer to I5
+// i I5: changed from alias of io.Writer to defined type with underlying interface{Write(p []byte) (n int, err error)}, so code that uses I5 and io.Writer interchangeably may break
 // In old, I5 and io.Writer are the same type; in new,
 // they are different. That can break something like:
 //   var _ func(io.Writer) = func(pkg.I6) {}
@@ -420,10 +447,17 @@ type I5 io.Writer
 type I6 interface{ Write([]byte) (int, error) }
 
 // new
-// i I6: changed from I6 to io.Writer
+// i I6: changed from defined type with underlying interface{Write([]byte) (int, error)} to alias of io.Writer, so code that relies on I6 being distinct from io.Writer may break
 // Similar to the above.
 type I6 = io.Writer
 
+// old
+type AliasT = int
+
+// new
+// i AliasT: changed from alias of int to defined type with underlying string, so code that uses AliasT and int interchangeably may break
+type AliasT string
+
 //// correspondence with a basic type
 // Basic types are technically defined types, but they aren't
 // represented that way in go/types, so the cases below are special.
@@ -463,6 +497,24 @@ var VT3 t3
 // because old t3 has an exported method.
 var VT3 int
 
+// An unexported type returned by an exported function is exposed,
+// so its exported methods are part of the API.
+
+// old
+type builder struct{}
+
+func (*builder) Add()  {}
+func (*builder) Done() {}
+
+// new
+type builder struct{}
+
+// i (*builder).Add: removed
+func (*builder) Done() {}
+
+// both
+func NewBuilder() *builder { return nil }
+
 // old
 var VT4 int
 
@@ -488,6 +540,7 @@ func F4(int) int                  { return 0 }
 func F5(int) int                  { return 0 }
 func F6(int)                      {}
 func F7(interface{})              {}
+func F12(int) bool                { return false }
 
 // new
 func F1(c int, d string) map[u2]AA { return nil } //OK: same (since u1 corresponds to u2)
@@ -510,6 +563,9 @@ func F6(...int) {}
 // i F7: changed from func(interface{}) to func(interface{x()})
 func F7(a interface{ x() }) {}
 
+// i F12: changed from func(int) bool to func(int, ...string) bool, which breaks uses of the function as a value but not calls
+func F12(a int, opts ...string) bool { return false }
+
 // old
 func F8(bool) {}
 
@@ -746,18 +802,84 @@ func (*SM) P5() {}
 
 // func (*SM) p() {}  // OK: unexported method removed
 
-// Changing from a value to a pointer receiver or vice versa
-// just looks like adding and removing a method.
+// Changing from a value to a pointer receiver removes the method
+// from the value method set; the reverse adds it.
 
-// i SM.V4: removed
+// i SM.V4: changed from value to pointer receiver, removing it from the method set of SM
 // i (*SM).V4: changed from func() to func(int)
 func (*SM) V4(int) {}
 
-// c SM.P4: added
+// c SM.P4: changed from pointer to value receiver, adding it to the method set of SM
 // P4 is not removed from (*SM) because value methods
 // are in the pointer method set.
 func (SM) P4() {}
 
+// A method removed from a type is not a receiver change just because
+// an embedded type now provides a pointer method of the same name.
+
+// old
+type PromT struct{}
+
+func (PromT) M() {}
+
+// new
+type promE struct{}
+
+func (*promE) M() {}
+
+// i PromT.M: removed
+type PromT struct{ promE }
+
+// The same holds in reverse: a promoted value method does not make
+// a removed pointer method a receiver change.
+
+// old
+type PromU struct{}
+
+func (*PromU) M() {}
+
+// new
+type promF struct{}
+
+func (promF) M() {}
+
+// c promF.M: added
+type PromU struct{ promF }
+
+// old
+type VarM int
+
+func (VarM) M(int) {}
+
+type VarI interface {
+	M(int)
+}
+
+// The receiver check also applies to the package: a promoted method of
+// an embedded type with the same name from another package is not a
+// receiver change.
+
+// old
+type Month struct{}
+
+func (*Month) String() string { return "" }
+
+// new
+// c Month.Month: added
+// c Month.String: added
+type Month struct{ time.Month }
+
+// new
+type VarM int
+
+// i VarM.M: changed from func(int) to func(int, ...int), which breaks interface implementations and uses of the method as a value but not calls
+func (VarM) M(int, ...int) {}
+
+type VarI interface {
+	// i VarI.M: changed from func(int) to func(int, ...int)
+	M(int, ...int)
+}
+
 type embedm2 int
 
 // i embedm.EV1: changed from func() to func(int)
@@ -836,6 +958,27 @@ type WS2 int
 func (WS2) M2() {}
 func (WS2) m2() {}
 
+// old
+type WI3 interface {
+	W3()
+}
+
+type WS3 int
+
+func (WS3) W3() {}
+
+// new
+type WI3 interface {
+	W3()
+	// i WI3.W4: added, so existing implementations of the interface no longer satisfy it
+	W4()
+}
+
+// i WS3: no longer implements WI3
+type WS3 int
+
+func (WS3) W3() {}
+
 //////////////// Miscellany
 
 // This verifies that the code works even through